@@ -9,7 +9,9 @@ import (
 	"log"
 	"net/http"
 	"os"
-	"strings"
+
+	multierror "github.com/mino-park7/go-study/pkg/errors"
+	"github.com/mino-park7/go-study/pkg/httperr"
 )
 
 //===============================================
@@ -87,8 +89,8 @@ func test() {
 	fmt.Println(bankCustomer.balance)
 
 	customer := Customer{Age: 25, Name: "Minho"}
-	// Results in "cusotmer is invalid : <nil>"
-	// err is always not nil here. since it's "error" object with "nil" value inside.
+	// err is now a real nil here, since Validate no longer returns a
+	// typed-nil *MultiError through the error interface.
 	if err := customer.Validate(); err != nil {
 		log.Fatalf("Validation failed: %v", err)
 	}
@@ -147,44 +149,26 @@ func (l loc) getCoordinates(ctx context.Context, address string) (lat, lng float
 // Rule 45 Returning a nil receiver
 //===============================================
 
-type MultiError struct {
-	errs []string
-}
-
-func (m *MultiError) myAdd(err error) {
-	m.errs = append(m.errs, err.Error())
-}
-
-func (m *MultiError) Error() string {
-	return strings.Join(m.errs, ";")
-}
-
 type Customer struct {
 	Age  int
 	Name string
 }
 
+// Validate used to accumulate into a hand-rolled *MultiError and return it
+// directly, which meant a still-nil *MultiError got wrapped in a non-nil
+// error interface: the classic nil-receiver-in-an-interface footgun.
+// multierror.Append only ever returns a non-nil error when something was
+// actually appended, so this accumulator can't recreate that bug.
 func (c Customer) Validate() error {
-	var m *MultiError
+	var err error
 	if c.Age < 0 {
-		m = &MultiError{}
-		m.myAdd(errors.New("age is negative"))
+		err = multierror.Append(err, errors.New("age is negative"))
 	}
 	if c.Name == "" {
-		if m == nil {
-			m = &MultiError{}
-		}
-		m.myAdd(errors.New("name is nil"))
+		err = multierror.Append(err, errors.New("name is nil"))
 	}
 
-	// fix : check for nil before wrapping it with error interface
-	// if m != nil{
-	// 	return m
-	// }
-	// return nil
-
-	// If all checks are valid, argument provided to return isn't nil, but it will be an error interface pointing to nil.
-	return m
+	return err
 }
 
 //===============================================
@@ -344,6 +328,12 @@ func (t transientError) Error() string {
 	return fmt.Sprintf("traisient error: %v", t.err)
 }
 
+func init() {
+	httperr.Register(transientError{}, http.StatusServiceUnavailable, nil, func(err error) any {
+		return map[string]string{"error": err.Error()}
+	})
+}
+
 func getTransactionAmountFromDB(transactionID string) (float32, error) {
 	return 0, errors.New("transaction not found")
 }
@@ -407,21 +397,16 @@ func handler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// Here's the handler that chekcs the error type.
+// Here's the handler that checks the error type, now via httperr, which
+// does internally what this handler used to do by hand: walk the wrap
+// chain with errors.As against the types registered with httperr.Register
+// (transientError -> 503 here), then fall back to a default.
 func fixedHandler(w http.ResponseWriter, r *http.Request) {
 	// transactionID := r.URL.Query().Get("transaction")
 
-	// Now, returned error is not directly transientError, but it's wrapped with fmt.Errorf.
-	// In this case, it will be always 400 error.
 	_, err := getTransactionAmount2(r.URL.Query().Get("id"))
 	if err != nil {
-		// Now, errors.As recursively unwraps the error, and checks if it's a transientError.
-		// This feature is added from Go 1.13.
-		if errors.As(err, &transientError{}) {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-		} else {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
+		httperr.Write(w, r, err)
 		return
 	}
 }
@@ -1,23 +1,27 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
 	"math/rand"
 	"sync"
 	"sync/atomic"
 	"time"
-)
 
-// This controls the maxprocs environment variable in container runtimes.
-// see https://martin.baillie.id/wrote/gotchas-in-the-go-network-packages-defaults/#bonus-gomaxprocs-containers-and-the-cfs
+	"github.com/mino-park7/go-study/pkg/psort"
+	"github.com/mino-park7/go-study/pkg/runtimeenv"
+)
 
 func main() {
+	ctx := context.Background()
 
-	// // Logger configuration
-	// logger := log.New(
-	// 	log.WithLevel(os.Getenv("LOG_LEVEL")),
-	// 	log.WithSource(),
-	// )
+	logger, shutdown, err := runtimeenv.Bootstrap(ctx)
+	if err != nil {
+		panic(fmt.Errorf("bootstrapping runtime environment: %w", err))
+	}
+	defer shutdown()
+	defer runtimeenv.Recover(logger)
 
 	// #51 배열과 슬라이스를 명확히 구분하라
 	// a := [3]int{0, 1, 2}
@@ -35,9 +39,15 @@ func main() {
 	// }
 
 	// #56 동시성이 무조건 빠르다고 착각하지 마라
-	// benchmarkMergeSort(sequentialMergeSort)
-	// benchmarkMergeSort(parallelMergeSortV1)
-	// benchmarkMergeSort(parallelMergeSortV2)
+	// pkg/psort's own go test -bench harness is what actually compares the
+	// sequential, fork-join and work-stealing variants; here we just show
+	// that Sort's scheduler is pluggable via options.
+	benchmarkMergeSort(ctx, logger, "fork-join", func(s []int) {
+		psort.Sort(s, func(a, b int) bool { return a < b }, psort.WithScheduler(psort.SchedulerForkJoin))
+	})
+	benchmarkMergeSort(ctx, logger, "work-stealing", func(s []int) {
+		psort.Sort(s, func(a, b int) bool { return a < b }, psort.WithScheduler(psort.SchedulerWorkStealing))
+	})
 
 	// #58 경쟁 문제에 대해 완전히 이해하라
 
@@ -59,7 +69,7 @@ func main() {
 	}()
 
 	wg.Wait()
-	fmt.Println(i)
+	logger.InfoContext(ctx, "data race demo", slog.Int("i", i))
 
 	// atomic
 	var i2 int64
@@ -79,7 +89,7 @@ func main() {
 	}()
 
 	wg2.Wait()
-	fmt.Println(i2)
+	logger.InfoContext(ctx, "atomic demo", slog.Int64("i2", i2))
 
 	// mutex
 	i3 := 0
@@ -104,7 +114,7 @@ func main() {
 	}()
 
 	w3.Wait()
-	fmt.Println(i3)
+	logger.InfoContext(ctx, "mutex demo", slog.Int("i3", i3))
 
 	// channel
 	i4 := 0
@@ -126,7 +136,7 @@ func main() {
 	i4 += <-ch
 
 	wg4.Wait()
-	fmt.Println(i4)
+	logger.InfoContext(ctx, "channel demo", slog.Int("i4", i4))
 
 	// race condition
 
@@ -145,25 +155,10 @@ func main() {
 	}()
 
 	wg5.Wait()
-	fmt.Println(i5)
+	logger.InfoContext(ctx, "race condition demo", slog.Int("i5", i5))
 }
 
-// func run(logger *slog.Logger) error {
-// 	ctx := context.Background()
-
-// 	_, err := maxprocs.Set(maxprocs.Logger(func(s string, i ...interface{}) {
-// 		logger.DebugContext(ctx, fmt.Sprintf(s, i...))
-// 	}))
-// 	if err != nil {
-// 		return fmt.Errorf("setting max procs: %w", err)
-// 	}
-
-// 	logger.InfoContext(ctx, "Hello world!", slog.String("location", "world"))
-
-// 	return nil
-// }
-
-func benchmarkMergeSort(sortFunc func([]int)) {
+func benchmarkMergeSort(ctx context.Context, logger *slog.Logger, name string, sortFunc func([]int)) {
 	const benchmarkRuns = 5
 	const arraySize = 10000
 
@@ -181,5 +176,5 @@ func benchmarkMergeSort(sortFunc func([]int)) {
 	}
 
 	avgTime := totalTime / benchmarkRuns
-	fmt.Printf("\nAverage time over %d runs: %s\n", benchmarkRuns, avgTime)
+	logger.InfoContext(ctx, "merge sort benchmark", slog.String("variant", name), slog.Int("runs", benchmarkRuns), slog.Duration("avg", avgTime))
 }
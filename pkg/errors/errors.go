@@ -0,0 +1,120 @@
+// Package errors extends the standard library's errors package with a
+// multi-error type that follows the Go 1.20 Join/Unwrap([]error) convention,
+// so errors.Is and errors.As traverse every child error instead of stopping
+// at the first one.
+//
+// It exists to fix the footgun described in Rule 45: a hand-rolled
+// *MultiError accumulator that is still nil at the end of a function gets
+// wrapped in a non-nil error interface when it's returned, so callers see a
+// non-nil error even though nothing actually failed. Append and Join only
+// ever return a non-nil error when there is at least one non-nil error to
+// report.
+package errors
+
+import (
+	"fmt"
+	"strings"
+)
+
+// multiError is the concrete type returned by Join and Append.
+type multiError struct {
+	errs []error
+}
+
+func (m *multiError) Error() string {
+	var b strings.Builder
+	for i, err := range m.errs {
+		if i > 0 {
+			b.WriteString("; ")
+		}
+		b.WriteString(err.Error())
+	}
+	return b.String()
+}
+
+// Unwrap returns every child error so errors.Is and errors.As can traverse
+// all of them, not just the first.
+func (m *multiError) Unwrap() []error {
+	return m.errs
+}
+
+// Format implements fmt.Formatter. %+v renders one error per line in a
+// stack-trace style; every other verb falls back to Error().
+func (m *multiError) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('+') {
+		for i, err := range m.errs {
+			if i > 0 {
+				fmt.Fprint(f, "\n")
+			}
+			fmt.Fprintf(f, "- %+v", err)
+		}
+		return
+	}
+	fmt.Fprint(f, m.Error())
+}
+
+// Join returns an error wrapping every non-nil error in errs. Nil errors are
+// skipped, and Join returns nil if every argument is nil, so the returned
+// error is never a typed nil hiding behind the error interface.
+func Join(errs ...error) error {
+	return Append(nil, errs...)
+}
+
+// Append adds errs to dst and returns the combined error. If dst is already
+// a *multiError (as returned by Join or a previous call to Append), its
+// children are flattened into the result rather than nested. Nil errors,
+// including a nil dst, are ignored, and Append returns nil if nothing
+// non-nil remains. This makes the usual accumulation pattern safe:
+//
+//	var err error
+//	err = errors.Append(err, mayFail())
+//	err = errors.Append(err, mayAlsoFail())
+//	return err // nil unless something actually failed
+func Append(dst error, errs ...error) error {
+	var all []error
+	if dst != nil {
+		if m, ok := dst.(*multiError); ok {
+			all = append(all, m.errs...)
+		} else {
+			all = append(all, dst)
+		}
+	}
+	for _, err := range errs {
+		if err != nil {
+			all = append(all, err)
+		}
+	}
+	if len(all) == 0 {
+		return nil
+	}
+	return &multiError{errs: all}
+}
+
+// Filter removes every error from err for which pred returns true. It
+// unwraps a multi-error returned by Join/Append so each child is tested
+// independently, and returns nil if nothing survives the filter. Callers
+// use this to strip sentinel or transient errors they don't want to
+// propagate, e.g. Filter(err, func(e error) bool { return errors.Is(e, ErrTransient) }).
+func Filter(err error, pred func(error) bool) error {
+	if err == nil {
+		return nil
+	}
+	m, ok := err.(*multiError)
+	if !ok {
+		if pred(err) {
+			return nil
+		}
+		return err
+	}
+
+	var kept []error
+	for _, e := range m.errs {
+		if !pred(e) {
+			kept = append(kept, e)
+		}
+	}
+	if len(kept) == 0 {
+		return nil
+	}
+	return &multiError{errs: kept}
+}
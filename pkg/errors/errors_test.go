@@ -0,0 +1,80 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestJoinNilSafe(t *testing.T) {
+	if err := Join(nil, nil, nil); err != nil {
+		t.Fatalf("Join(nil, nil, nil) = %v, want nil", err)
+	}
+	if err := Join(); err != nil {
+		t.Fatalf("Join() = %v, want nil", err)
+	}
+}
+
+func TestAppendNilSafe(t *testing.T) {
+	var err error
+	err = Append(err, nil)
+	if err != nil {
+		t.Fatalf("Append(nil, nil) = %v, want nil", err)
+	}
+}
+
+func TestAppendAccumulatesAndFlattens(t *testing.T) {
+	e1 := errors.New("age is negative")
+	e2 := errors.New("name is nil")
+
+	var err error
+	err = Append(err, e1)
+	err = Append(err, e2)
+
+	if got := err.Error(); got != "age is negative; name is nil" {
+		t.Fatalf("Error() = %q, want %q", got, "age is negative; name is nil")
+	}
+
+	if !errors.Is(err, e1) || !errors.Is(err, e2) {
+		t.Fatalf("errors.Is did not find both children in %v", err)
+	}
+}
+
+func TestUnwrapForErrorsAs(t *testing.T) {
+	type myErr struct{ error }
+	target := myErr{errors.New("boom")}
+
+	err := Join(errors.New("other"), target)
+
+	var got myErr
+	if !errors.As(err, &got) {
+		t.Fatalf("errors.As failed to find %v in %v", target, err)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	sentinel := errors.New("transient")
+	err := Join(errors.New("real problem"), sentinel)
+
+	filtered := Filter(err, func(e error) bool { return errors.Is(e, sentinel) })
+	if errors.Is(filtered, sentinel) {
+		t.Fatalf("Filter did not remove sentinel: %v", filtered)
+	}
+	if !errors.Is(filtered, err.(*multiError).errs[0]) {
+		t.Fatalf("Filter removed the error it shouldn't have: %v", filtered)
+	}
+
+	if got := Filter(sentinel, func(e error) bool { return errors.Is(e, sentinel) }); got != nil {
+		t.Fatalf("Filter(sentinel, ...) = %v, want nil", got)
+	}
+}
+
+func TestFormatPlusV(t *testing.T) {
+	err := Join(errors.New("first"), errors.New("second"))
+
+	got := fmt.Sprintf("%+v", err)
+	want := "- first\n- second"
+	if got != want {
+		t.Fatalf("Sprintf(%%+v) = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,165 @@
+// Package httperr maps application errors to HTTP responses in one place,
+// so handlers don't each have to switch on error types themselves (Rules
+// 49-51: wrap errors deliberately, check their type with errors.As rather
+// than a type switch, and check sentinel values with errors.Is rather than
+// ==). Modules register how a given error type should be rendered; a
+// single httperr.Write(w, r, err) call at the edge then walks err's wrap
+// chain and picks the right response.
+package httperr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"sync"
+)
+
+// statusClientClosedRequest is the nginx-popularized, non-standard status
+// used for a request the client canceled before the server could respond.
+// There is no status in net/http for context.Canceled.
+const statusClientClosedRequest = 499
+
+// Mapping describes how a registered error type becomes an HTTP response.
+type Mapping struct {
+	Status       int
+	Headers      map[string]string
+	BodyRenderer func(error) any
+}
+
+// entry pairs a registered type with its mapping. registry is kept as a
+// slice, walked in registration order, rather than a map: a map's
+// iteration order is randomized on every run, and once an error's wrap
+// chain (e.g. a multierror.Join from pkg/errors) matches more than one
+// registered type, ranging over a map would pick a different winner on
+// every call for the same input.
+type entry struct {
+	errType reflect.Type
+	mapping Mapping
+}
+
+var (
+	registryMu  sync.RWMutex
+	registryIdx = map[reflect.Type]int{}
+	registry    []entry
+)
+
+// Register records that any error whose concrete type matches target's
+// (found anywhere in an error's wrap chain via errors.As) should be
+// rendered using the given status, headers, and bodyRenderer. bodyRenderer
+// may be nil, in which case Write sends an empty body. Registering the
+// same type again replaces its mapping without changing its position in
+// resolution order; a new type is appended after every existing one.
+func Register(target error, status int, headers map[string]string, bodyRenderer func(error) any) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	t := reflect.TypeOf(target)
+	m := Mapping{Status: status, Headers: headers, BodyRenderer: bodyRenderer}
+	if i, ok := registryIdx[t]; ok {
+		registry[i].mapping = m
+		return
+	}
+	registryIdx[t] = len(registry)
+	registry = append(registry, entry{errType: t, mapping: m})
+}
+
+// Middleware recovers a panic from next, logging and rendering it through
+// Write instead of letting it reach net/http's default recoverer. A panic
+// value that is not an error is wrapped in one; the response is a 500 unless
+// Write maps the resulting error to another status.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			if rec := recover(); rec != nil {
+				err, ok := rec.(error)
+				if !ok {
+					err = fmt.Errorf("panic: %v", rec)
+				}
+				Write(w, r, err)
+			}
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Write resolves err to an HTTP status, headers, and body, logs the full
+// unwrap chain, and writes the response. It checks registered type
+// mappings first (errors.As, so it finds a match anywhere in the wrap
+// chain), then the built-in context.Canceled/context.DeadlineExceeded
+// sentinels (errors.Is), and falls back to 500 if nothing matches.
+func Write(w http.ResponseWriter, r *http.Request, err error) {
+	status, headers, body := resolve(err)
+
+	slog.ErrorContext(r.Context(), "request failed",
+		slog.Int("status", status), slog.Any("chain", unwrapChain(err)))
+
+	for k, v := range headers {
+		w.Header().Set(k, v)
+	}
+	if body == nil {
+		w.WriteHeader(status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func resolve(err error) (status int, headers map[string]string, body any) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	for _, e := range registry {
+		target := reflect.New(e.errType).Interface()
+		if !errors.As(err, target) {
+			continue
+		}
+		matched := reflect.ValueOf(target).Elem().Interface().(error)
+		var b any
+		if e.mapping.BodyRenderer != nil {
+			b = e.mapping.BodyRenderer(matched)
+		}
+		return e.mapping.Status, e.mapping.Headers, b
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return statusClientClosedRequest, nil, nil
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout, nil, nil
+	}
+
+	return http.StatusInternalServerError, nil, nil
+}
+
+// unwrapChain renders err and everything it wraps (single-error Unwrap()
+// error or multi-error Unwrap() []error, see pkg/errors) as one message
+// per entry, in wrap order, for structured logging.
+func unwrapChain(err error) []string {
+	var chain []string
+	seen := map[error]bool{}
+
+	var walk func(error)
+	walk = func(e error) {
+		if e == nil || seen[e] {
+			return
+		}
+		seen[e] = true
+		chain = append(chain, e.Error())
+
+		switch x := e.(type) {
+		case interface{ Unwrap() error }:
+			walk(x.Unwrap())
+		case interface{ Unwrap() []error }:
+			for _, child := range x.Unwrap() {
+				walk(child)
+			}
+		}
+	}
+	walk(err)
+	return chain
+}
@@ -0,0 +1,106 @@
+package httperr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type notFoundError struct{ resource string }
+
+func (e notFoundError) Error() string { return fmt.Sprintf("%s not found", e.resource) }
+
+func TestWriteRegisteredType(t *testing.T) {
+	Register(notFoundError{}, http.StatusNotFound, map[string]string{"X-Reason": "missing"}, func(err error) any {
+		return map[string]string{"error": err.Error()}
+	})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	Write(rec, req, fmt.Errorf("lookup failed: %w", notFoundError{resource: "widget"}))
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+	if got := rec.Header().Get("X-Reason"); got != "missing" {
+		t.Fatalf("X-Reason header = %q, want %q", got, "missing")
+	}
+}
+
+func TestWriteContextSentinels(t *testing.T) {
+	tests := map[error]int{
+		context.Canceled:         statusClientClosedRequest,
+		context.DeadlineExceeded: http.StatusGatewayTimeout,
+	}
+
+	for err, wantStatus := range tests {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+		Write(rec, req, fmt.Errorf("wrapped: %w", err))
+
+		if rec.Code != wantStatus {
+			t.Errorf("Write(%v) status = %d, want %d", err, rec.Code, wantStatus)
+		}
+	}
+}
+
+func TestWriteDefaultsTo500(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	Write(rec, req, errors.New("something unexpected"))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+}
+
+type rateLimitError struct{}
+
+func (rateLimitError) Error() string { return "rate limited" }
+
+type conflictError struct{}
+
+func (conflictError) Error() string { return "conflict" }
+
+// TestResolveIsDeterministicForOverlappingMatches guards against a map
+// iteration order regression: once an error (e.g. one built with
+// pkg/errors.Join) matches more than one registered type, resolve must
+// always pick the same one, not a different one on every call.
+func TestResolveIsDeterministicForOverlappingMatches(t *testing.T) {
+	Register(rateLimitError{}, http.StatusNotImplemented, nil, nil)
+	Register(conflictError{}, http.StatusBadGateway, nil, nil)
+
+	joined := errors.Join(rateLimitError{}, conflictError{})
+
+	for i := 0; i < 50; i++ {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+		Write(rec, req, joined)
+
+		if rec.Code != http.StatusNotImplemented {
+			t.Fatalf("iteration %d: status = %d, want %d (first-registered type should always win)", i, rec.Code, http.StatusNotImplemented)
+		}
+	}
+}
+
+func TestMiddlewareRecoversPanic(t *testing.T) {
+	h := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(errors.New("boom"))
+	}))
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,84 @@
+package psort
+
+import (
+	"sort"
+	"sync"
+)
+
+// parallelMergeSortV1 is the naive fork-join merge sort: it spawns two
+// goroutines per recursive call, all the way down to single-element
+// slices. It's kept around, and exercised by the benchmarks, to demonstrate
+// the Rule 56 pitfall first-hand: goroutine and scheduling overhead
+// dominates once the subproblem is small, so this version typically loses
+// to sequentialMergeSort on realistic input sizes.
+func parallelMergeSortV1[T any](s []T, less func(a, b T) bool) {
+	if len(s) < 2 {
+		return
+	}
+
+	mid := len(s) / 2
+	left := make([]T, mid)
+	right := make([]T, len(s)-mid)
+	copy(left, s[:mid])
+	copy(right, s[mid:])
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		parallelMergeSortV1(left, less)
+	}()
+	go func() {
+		defer wg.Done()
+		parallelMergeSortV1(right, less)
+	}()
+	wg.Wait()
+
+	merge(s, left, right, less)
+}
+
+// parallelMergeSortV2 fixes the two problems with V1: it falls back to
+// sort.SliceStable at or below c.sequentialCutoff instead of recursing to single
+// elements, and it bounds the number of concurrently running goroutines to
+// c.parallelism with a semaphore, running the rest of the split inline
+// once the budget is spent.
+func parallelMergeSortV2[T any](s []T, less func(a, b T) bool, c config) {
+	// The calling goroutine counts toward c.parallelism, so only
+	// c.parallelism-1 extra goroutines may be spawned.
+	sem := make(chan struct{}, c.parallelism-1)
+
+	var fork func([]T)
+	fork = func(s []T) {
+		if len(s) <= c.sequentialCutoff {
+			sort.SliceStable(s, func(i, j int) bool { return less(s[i], s[j]) })
+			return
+		}
+
+		mid := len(s) / 2
+		left := make([]T, mid)
+		right := make([]T, len(s)-mid)
+		copy(left, s[:mid])
+		copy(right, s[mid:])
+
+		select {
+		case sem <- struct{}{}:
+			var wg sync.WaitGroup
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() { <-sem }()
+				fork(left)
+			}()
+			fork(right)
+			wg.Wait()
+		default:
+			// Parallelism budget spent: finish this split on the calling
+			// goroutine instead of queueing more goroutines than requested.
+			fork(left)
+			fork(right)
+		}
+
+		merge(s, left, right, less)
+	}
+	fork(s)
+}
@@ -0,0 +1,46 @@
+package psort
+
+// sequentialMergeSort sorts s in place with a textbook single-goroutine
+// top-down merge sort. It's the baseline every parallel variant is
+// benchmarked against.
+func sequentialMergeSort[T any](s []T, less func(a, b T) bool) {
+	if len(s) < 2 {
+		return
+	}
+
+	mid := len(s) / 2
+	left := make([]T, mid)
+	right := make([]T, len(s)-mid)
+	copy(left, s[:mid])
+	copy(right, s[mid:])
+
+	sequentialMergeSort(left, less)
+	sequentialMergeSort(right, less)
+
+	merge(s, left, right, less)
+}
+
+// merge writes the merged contents of left and right, both already sorted,
+// into dst. Taking from left whenever neither side is strictly less than
+// the other keeps the merge stable.
+func merge[T any](dst, left, right []T, less func(a, b T) bool) {
+	i, j, k := 0, 0, 0
+	for i < len(left) && j < len(right) {
+		if less(right[j], left[i]) {
+			dst[k] = right[j]
+			j++
+		} else {
+			dst[k] = left[i]
+			i++
+		}
+		k++
+	}
+	for ; i < len(left); i++ {
+		dst[k] = left[i]
+		k++
+	}
+	for ; j < len(right); j++ {
+		dst[k] = right[j]
+		k++
+	}
+}
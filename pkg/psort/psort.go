@@ -0,0 +1,102 @@
+// Package psort implements merge sort over generic slices at four levels
+// of sophistication: sequential, naive goroutine-per-call fork-join, a
+// bounded fork-join that falls back to sort.SliceStable at or below a cutoff, and a
+// work-stealing scheduler with one deque per worker.
+//
+// It exists as a hands-on illustration of Rule 56: concurrency isn't
+// always faster. sequentialMergeSort and parallelMergeSortV1 are kept
+// around (and exercised by the benchmarks) specifically to show that
+// spawning a goroutine per recursive call loses to the sequential version
+// once the subproblem is small enough that goroutine and synchronization
+// overhead dominates the actual sort work.
+package psort
+
+import "runtime"
+
+// Scheduler selects the strategy Sort's parallel path uses once the
+// input is larger than WithSequentialCutoff.
+type Scheduler int
+
+const (
+	// SchedulerForkJoin recursively splits the input in half, bounding the
+	// number of concurrently running goroutines to WithParallelism.
+	SchedulerForkJoin Scheduler = iota
+	// SchedulerWorkStealing runs a fixed pool of workers, each owning a
+	// deque of pending subtasks; idle workers steal from one another.
+	SchedulerWorkStealing
+)
+
+// defaultSequentialCutoff is the slice length at or below which Sort falls
+// back to a sequential stable sort instead of recursing further.
+const defaultSequentialCutoff = 2048
+
+type config struct {
+	parallelism      int
+	sequentialCutoff int
+	scheduler        Scheduler
+}
+
+// Option configures Sort's parallel behavior.
+type Option func(*config)
+
+// WithParallelism sets how many goroutines (SchedulerForkJoin) or workers
+// (SchedulerWorkStealing) Sort may use. The default is runtime.GOMAXPROCS(0).
+func WithParallelism(n int) Option {
+	return func(c *config) {
+		if n > 0 {
+			c.parallelism = n
+		}
+	}
+}
+
+// WithSequentialCutoff sets the slice length at or below which Sort falls
+// back to a sequential stable sort (sequentialMergeSort for the whole input,
+// sort.SliceStable for a split-off part) instead of splitting further. This avoids the pitfall Rule 56
+// warns about: below some size, the overhead of coordinating goroutines
+// costs more than just sorting the slice directly. The default is 2048.
+func WithSequentialCutoff(k int) Option {
+	return func(c *config) {
+		if k > 0 {
+			c.sequentialCutoff = k
+		}
+	}
+}
+
+// WithScheduler selects the parallel strategy. The default is SchedulerForkJoin.
+func WithScheduler(s Scheduler) Option {
+	return func(c *config) { c.scheduler = s }
+}
+
+func newConfig(opts ...Option) config {
+	c := config{
+		parallelism:      runtime.GOMAXPROCS(0),
+		sequentialCutoff: defaultSequentialCutoff,
+		scheduler:        SchedulerForkJoin,
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// Sort sorts s in place using less to compare elements, in ascending order,
+// using a parallel merge sort once s is larger than WithSequentialCutoff.
+// The sort is stable: equal elements keep their relative order.
+func Sort[T any](s []T, less func(a, b T) bool, opts ...Option) {
+	if len(s) < 2 {
+		return
+	}
+
+	c := newConfig(opts...)
+	if len(s) <= c.sequentialCutoff {
+		sequentialMergeSort(s, less)
+		return
+	}
+
+	switch c.scheduler {
+	case SchedulerWorkStealing:
+		workStealingSort(s, less, c)
+	default:
+		parallelMergeSortV2(s, less, c)
+	}
+}
@@ -0,0 +1,81 @@
+package psort
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func benchmarkInput(b *testing.B, n int) []int {
+	b.Helper()
+	s := make([]int, n)
+	for i := range s {
+		s[i] = rand.Intn(n)
+	}
+	return s
+}
+
+func lessInt(a, b int) bool { return a < b }
+
+var benchSizes = []int{1e3, 1e4, 1e5, 1e6, 1e7}
+
+// forkJoinV1BenchSizes intentionally stops well short of benchSizes' 1e7:
+// parallelMergeSortV1 spawns two goroutines per recursive call all the way
+// down to single elements, so its goroutine/scheduling overhead grows with
+// the input size rather than staying bounded. At 1e6 elements it already
+// takes several seconds per b.N iteration; at 1e7 a single iteration would
+// take minutes, which is the whole point of the comparison (Rule 56) but
+// makes `go test -bench` impractically slow.
+var forkJoinV1BenchSizes = []int{1e3, 1e4, 1e5}
+
+func BenchmarkSequential(b *testing.B) {
+	for _, n := range benchSizes {
+		input := benchmarkInput(b, n)
+		b.Run(sizeName(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				s := append([]int(nil), input...)
+				sequentialMergeSort(s, lessInt)
+			}
+		})
+	}
+}
+
+func BenchmarkForkJoinV1(b *testing.B) {
+	for _, n := range forkJoinV1BenchSizes {
+		input := benchmarkInput(b, n)
+		b.Run(sizeName(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				s := append([]int(nil), input...)
+				parallelMergeSortV1(s, lessInt)
+			}
+		})
+	}
+}
+
+func BenchmarkForkJoinV2(b *testing.B) {
+	for _, n := range benchSizes {
+		input := benchmarkInput(b, n)
+		b.Run(sizeName(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				s := append([]int(nil), input...)
+				Sort(s, lessInt, WithScheduler(SchedulerForkJoin))
+			}
+		})
+	}
+}
+
+func BenchmarkWorkStealing(b *testing.B) {
+	for _, n := range benchSizes {
+		input := benchmarkInput(b, n)
+		b.Run(sizeName(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				s := append([]int(nil), input...)
+				Sort(s, lessInt, WithScheduler(SchedulerWorkStealing))
+			}
+		})
+	}
+}
+
+func sizeName(n int) string {
+	return fmt.Sprintf("n=%d", n)
+}
@@ -0,0 +1,77 @@
+package psort
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+type record struct {
+	key    int
+	origin int // original index, to verify stability
+}
+
+func randomRecords(n, keySpace int) []record {
+	recs := make([]record, n)
+	for i := range recs {
+		recs[i] = record{key: rand.Intn(keySpace), origin: i}
+	}
+	return recs
+}
+
+func isSortedAndStable(t *testing.T, recs []record) {
+	t.Helper()
+	for i := 1; i < len(recs); i++ {
+		if recs[i].key < recs[i-1].key {
+			t.Fatalf("not sorted at %d: %+v then %+v", i, recs[i-1], recs[i])
+		}
+		if recs[i].key == recs[i-1].key && recs[i].origin < recs[i-1].origin {
+			t.Fatalf("not stable at %d: %+v then %+v", i, recs[i-1], recs[i])
+		}
+	}
+}
+
+func less(a, b record) bool { return a.key < b.key }
+
+func TestSortVariants(t *testing.T) {
+	// A small key space guarantees heavy duplication, which is what
+	// exposes a stability regression.
+	sizes := []int{0, 1, 2, 100, 5000}
+
+	variants := map[string]func([]record){
+		"sequential": func(s []record) { sequentialMergeSort(s, less) },
+		"forkJoinV1": func(s []record) { parallelMergeSortV1(s, less) },
+		"Sort/forkJoin": func(s []record) {
+			Sort(s, less, WithSequentialCutoff(32), WithScheduler(SchedulerForkJoin))
+		},
+		"Sort/workStealing": func(s []record) {
+			Sort(s, less, WithSequentialCutoff(32), WithScheduler(SchedulerWorkStealing), WithParallelism(4))
+		},
+	}
+
+	for name, sortFn := range variants {
+		name, sortFn := name, sortFn
+		t.Run(name, func(t *testing.T) {
+			for _, n := range sizes {
+				recs := randomRecords(n, 8)
+				sortFn(recs)
+				isSortedAndStable(t, recs)
+			}
+		})
+	}
+}
+
+func TestSortMatchesSortStable(t *testing.T) {
+	recs := randomRecords(20000, 16)
+	want := make([]record, len(recs))
+	copy(want, recs)
+	sort.SliceStable(want, func(i, j int) bool { return less(want[i], want[j]) })
+
+	Sort(recs, less, WithSequentialCutoff(64), WithScheduler(SchedulerWorkStealing))
+
+	for i := range recs {
+		if recs[i] != want[i] {
+			t.Fatalf("mismatch at %d: got %+v, want %+v", i, recs[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package psort
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+)
+
+// wsTask is a pending "sort this subslice, then signal done" unit of work.
+// run takes the id of whichever worker ends up executing it, so that any
+// further subdivisions it performs are pushed onto that worker's own
+// deque rather than the deque of whoever originally created the task.
+type wsTask struct {
+	run  func(executor int)
+	done chan struct{}
+}
+
+// wsDeque is a mutex-guarded double-ended queue of pending tasks. The
+// owning worker pushes and pops from the bottom (the tail of tasks) in
+// LIFO order, so it keeps working on the subtask it most recently split
+// off for cache locality. Other workers steal from the top (the head) in
+// FIFO order, which hands out the oldest, typically largest, subtasks
+// first, and leaves the owner's small, recent work alone.
+type wsDeque struct {
+	mu    sync.Mutex
+	tasks []*wsTask
+}
+
+func (d *wsDeque) pushBottom(t *wsTask) {
+	d.mu.Lock()
+	d.tasks = append(d.tasks, t)
+	d.mu.Unlock()
+}
+
+func (d *wsDeque) popBottom() (*wsTask, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	n := len(d.tasks)
+	if n == 0 {
+		return nil, false
+	}
+	t := d.tasks[n-1]
+	d.tasks = d.tasks[:n-1]
+	return t, true
+}
+
+func (d *wsDeque) stealTop() (*wsTask, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.tasks) == 0 {
+		return nil, false
+	}
+	t := d.tasks[0]
+	d.tasks = d.tasks[1:]
+	return t, true
+}
+
+// workStealingSort sorts s using c.parallelism workers, each with its own
+// wsDeque. The caller's own goroutine plays the role of worker 0 and
+// drives the top-level split directly; c.parallelism-1 background workers
+// exist purely to steal and execute subtasks the caller splits off.
+func workStealingSort[T any](s []T, less func(a, b T) bool, c config) {
+	numWorkers := c.parallelism
+	if numWorkers < 1 {
+		numWorkers = 1
+	}
+	if numWorkers == 1 {
+		sequentialMergeSort(s, less)
+		return
+	}
+
+	deques := make([]*wsDeque, numWorkers)
+	for i := range deques {
+		deques[i] = &wsDeque{}
+	}
+
+	var divide func(owner int, s []T)
+	divide = func(owner int, s []T) {
+		if len(s) <= c.sequentialCutoff {
+			sort.SliceStable(s, func(i, j int) bool { return less(s[i], s[j]) })
+			return
+		}
+
+		mid := len(s) / 2
+		left := make([]T, mid)
+		right := make([]T, len(s)-mid)
+		copy(left, s[:mid])
+		copy(right, s[mid:])
+
+		rightTask := &wsTask{done: make(chan struct{})}
+		rightTask.run = func(executor int) {
+			divide(executor, right)
+			close(rightTask.done)
+		}
+		deques[owner].pushBottom(rightTask)
+
+		divide(owner, left)
+
+		// By the time our own left recursion returns, every task it (and
+		// its descendants) pushed has already been resolved, so the only
+		// thing that can still be sitting at our deque's bottom is
+		// rightTask itself. If it's still there, nobody stole it: run it
+		// ourselves instead of paying for a handoff. Otherwise, some other
+		// worker is already running it; wait for it to finish.
+		if t, ok := deques[owner].popBottom(); ok {
+			t.run(owner)
+		} else {
+			<-rightTask.done
+		}
+
+		merge(s, left, right, less)
+	}
+
+	stop := make(chan struct{})
+	var workers sync.WaitGroup
+	workers.Add(numWorkers - 1)
+	for id := 1; id < numWorkers; id++ {
+		go func(id int) {
+			defer workers.Done()
+			wsWorkerLoop(id, deques, stop)
+		}(id)
+	}
+
+	divide(0, s)
+
+	close(stop)
+	workers.Wait()
+}
+
+// wsWorkerLoop runs until stop is closed, repeatedly trying to pop its own
+// deque, then trying to steal from a random victim, backing off
+// exponentially (capped) each time both come up empty.
+func wsWorkerLoop(id int, deques []*wsDeque, stop <-chan struct{}) {
+	const minBackoff = time.Microsecond
+	const maxBackoff = 2 * time.Millisecond
+	backoff := minBackoff
+
+	for {
+		if t, ok := deques[id].popBottom(); ok {
+			t.run(id)
+			backoff = minBackoff
+			continue
+		}
+
+		if victim := rand.Intn(len(deques)); victim != id {
+			if t, ok := deques[victim].stealTop(); ok {
+				t.run(id)
+				backoff = minBackoff
+				continue
+			}
+		}
+
+		select {
+		case <-stop:
+			return
+		case <-time.After(backoff):
+			if backoff *= 2; backoff > maxBackoff {
+				backoff = maxBackoff
+			}
+		}
+	}
+}
@@ -0,0 +1,189 @@
+// Package runtimeenv bootstraps a Go process for a container runtime.
+//
+// Outside a container the Go runtime reads the host's CPU count and physical
+// RAM, but inside one it's still only ever told about the host, not the
+// cgroup limits actually enforced on it. That mismatch is the GOMAXPROCS/CFS
+// gotcha: with more OS threads than quota'd CPUs, the scheduler oversubscribes
+// and gets throttled by the kernel. See
+// https://martin.baillie.id/wrote/gotchas-in-the-go-network-packages-defaults/#bonus-gomaxprocs-containers-and-the-cfs
+//
+// Bootstrap reads the cgroup (v1 or v2) CPU quota and memory limit, if any,
+// and right-sizes GOMAXPROCS and the runtime's soft memory limit accordingly,
+// then builds an environment-aware *slog.Logger.
+package runtimeenv
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+)
+
+// memoryLimitFraction is how much of the cgroup memory limit we give to
+// debug.SetMemoryLimit, leaving headroom for non-Go memory (thread stacks,
+// cgo allocations, the runtime's own bookkeeping).
+const memoryLimitFraction = 0.9
+
+// defaultCgroupRoot is where cpuQuota and memoryLimit look for cgroup files
+// in production. Tests point cpuQuota/memoryLimit at a temp dir instead.
+const defaultCgroupRoot = "/sys/fs/cgroup"
+
+// Bootstrap sets GOMAXPROCS from the cgroup CPU quota, sets the runtime's
+// soft memory limit from the cgroup memory limit, and builds a *slog.Logger.
+// It returns the logger and a shutdown func that restores the previous
+// slog default logger; callers own calling shutdown (typically via defer)
+// and should also defer Recover(logger) so panics get logged with a
+// symbolized stack before the process dies.
+func Bootstrap(ctx context.Context) (*slog.Logger, func(), error) {
+	logger := newLogger()
+
+	if quota, period, ok := cpuQuota(defaultCgroupRoot); ok {
+		n := int(quota / period)
+		if n < 1 {
+			n = 1
+		}
+		prev := runtime.GOMAXPROCS(n)
+		logger.InfoContext(ctx, "adjusted GOMAXPROCS from cgroup CPU quota",
+			slog.Int("gomaxprocs", n), slog.Int("previous", prev),
+			slog.Int64("quota_us", quota), slog.Int64("period_us", period))
+	}
+
+	if limit, ok := memoryLimit(defaultCgroupRoot); ok {
+		soft := int64(float64(limit) * memoryLimitFraction)
+		prev := debug.SetMemoryLimit(soft)
+		logger.InfoContext(ctx, "adjusted soft memory limit from cgroup",
+			slog.Int64("cgroup_limit_bytes", limit), slog.Int64("soft_limit_bytes", soft),
+			slog.Int64("previous_bytes", prev))
+	}
+
+	prevDefault := slog.Default()
+	slog.SetDefault(logger)
+
+	return logger, func() { slog.SetDefault(prevDefault) }, nil
+}
+
+// Recover logs a panic's recovered value and a symbolized stack trace
+// before re-panicking, so an operator can see what happened without the
+// panic being silently swallowed (Rule 48: recover to observe, not to hide).
+// Defer it directly, right after Bootstrap:
+//
+//	logger, shutdown, err := runtimeenv.Bootstrap(ctx)
+//	...
+//	defer shutdown()
+//	defer runtimeenv.Recover(logger)
+func Recover(logger *slog.Logger) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var stack strings.Builder
+	for {
+		frame, more := frames.Next()
+		fmt.Fprintf(&stack, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		if !more {
+			break
+		}
+	}
+
+	logger.Error("recovered from panic", slog.Any("panic", r), slog.String("stack", stack.String()))
+	panic(r)
+}
+
+// cpuQuota reports the cgroup CPU quota and period in microseconds, trying
+// cgroup v2's unified cpu.max first and falling back to cgroup v1's
+// cpu.cfs_quota_us/cpu.cfs_period_us, both under root. ok is false if no
+// quota is set (the container has no CPU limit, or isn't running under a
+// CPU-limiting cgroup).
+func cpuQuota(root string) (quota, period int64, ok bool) {
+	if data, err := os.ReadFile(filepath.Join(root, "cpu.max")); err == nil {
+		fields := strings.Fields(strings.TrimSpace(string(data)))
+		if len(fields) != 2 || fields[0] == "max" {
+			return 0, 0, false
+		}
+		q, errQ := strconv.ParseInt(fields[0], 10, 64)
+		p, errP := strconv.ParseInt(fields[1], 10, 64)
+		if errQ != nil || errP != nil || p <= 0 {
+			return 0, 0, false
+		}
+		return q, p, true
+	}
+
+	q, errQ := readCgroupInt(filepath.Join(root, "cpu", "cpu.cfs_quota_us"))
+	p, errP := readCgroupInt(filepath.Join(root, "cpu", "cpu.cfs_period_us"))
+	if errQ != nil || errP != nil || q <= 0 || p <= 0 {
+		return 0, 0, false
+	}
+	return q, p, true
+}
+
+// memoryLimit reports the cgroup memory limit in bytes, trying cgroup v2's
+// memory.max first and falling back to cgroup v1's memory.limit_in_bytes,
+// both under root. ok is false if no limit is set; cgroup v1 reports an
+// effectively unlimited sentinel value (close to MaxInt64) instead of
+// omitting the file, so values above half of MaxInt64 are treated the same
+// as "unset".
+func memoryLimit(root string) (int64, bool) {
+	if v, err := readCgroupInt(filepath.Join(root, "memory.max")); err == nil && v > 0 {
+		return v, true
+	}
+	if v, err := readCgroupInt(filepath.Join(root, "memory", "memory.limit_in_bytes")); err == nil && v > 0 && v < math.MaxInt64/2 {
+		return v, true
+	}
+	return 0, false
+}
+
+func readCgroupInt(path string) (int64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+}
+
+// newLogger builds a *slog.Logger whose level comes from LOG_LEVEL
+// (DEBUG/INFO/WARN/ERROR, default INFO) and whose handler is a JSON
+// handler by default, or a human-readable text handler when stdout is
+// attached to a terminal.
+func newLogger() *slog.Logger {
+	opts := &slog.HandlerOptions{Level: parseLevel(os.Getenv("LOG_LEVEL"))}
+
+	var handler slog.Handler
+	if isTTY(os.Stdout) {
+		handler = slog.NewTextHandler(os.Stdout, opts)
+	} else {
+		handler = slog.NewJSONHandler(os.Stdout, opts)
+	}
+	return slog.New(handler)
+}
+
+func parseLevel(s string) slog.Level {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case "DEBUG":
+		return slog.LevelDebug
+	case "WARN", "WARNING":
+		return slog.LevelWarn
+	case "ERROR":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+func isTTY(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
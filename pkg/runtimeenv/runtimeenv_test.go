@@ -0,0 +1,134 @@
+package runtimeenv
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeFixture creates dir/name with contents, creating dir's parents as
+// needed, so callers can then pass dir straight to cpuQuota / memoryLimit
+// as root.
+func writeFixture(t *testing.T, dir, name, contents string) {
+	t.Helper()
+	full := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+		t.Fatalf("MkdirAll(%q): %v", filepath.Dir(full), err)
+	}
+	if err := os.WriteFile(full, []byte(contents), 0o644); err != nil {
+		t.Fatalf("WriteFile(%q): %v", full, err)
+	}
+}
+
+func TestCPUQuotaV2(t *testing.T) {
+	root := t.TempDir()
+	writeFixture(t, root, "cpu.max", "200000 100000\n")
+
+	quota, period, ok := cpuQuota(root)
+	if !ok {
+		t.Fatal("cpuQuota() ok = false, want true")
+	}
+	if quota != 200000 || period != 100000 {
+		t.Fatalf("cpuQuota() = (%d, %d), want (200000, 100000)", quota, period)
+	}
+}
+
+func TestCPUQuotaV2Unlimited(t *testing.T) {
+	root := t.TempDir()
+	writeFixture(t, root, "cpu.max", "max 100000\n")
+
+	if _, _, ok := cpuQuota(root); ok {
+		t.Fatal("cpuQuota() ok = true for an unlimited (\"max\") quota, want false")
+	}
+}
+
+func TestCPUQuotaV1Fallback(t *testing.T) {
+	root := t.TempDir()
+	writeFixture(t, root, "cpu/cpu.cfs_quota_us", "50000\n")
+	writeFixture(t, root, "cpu/cpu.cfs_period_us", "100000\n")
+
+	quota, period, ok := cpuQuota(root)
+	if !ok {
+		t.Fatal("cpuQuota() ok = false, want true")
+	}
+	if quota != 50000 || period != 100000 {
+		t.Fatalf("cpuQuota() = (%d, %d), want (50000, 100000)", quota, period)
+	}
+}
+
+func TestCPUQuotaNoCgroup(t *testing.T) {
+	if _, _, ok := cpuQuota(t.TempDir()); ok {
+		t.Fatal("cpuQuota() ok = true with no cgroup files present, want false")
+	}
+}
+
+func TestMemoryLimitV2(t *testing.T) {
+	root := t.TempDir()
+	writeFixture(t, root, "memory.max", "134217728\n")
+
+	limit, ok := memoryLimit(root)
+	if !ok {
+		t.Fatal("memoryLimit() ok = false, want true")
+	}
+	if limit != 134217728 {
+		t.Fatalf("memoryLimit() = %d, want 134217728", limit)
+	}
+}
+
+func TestMemoryLimitV2Unlimited(t *testing.T) {
+	root := t.TempDir()
+	writeFixture(t, root, "memory.max", "max\n")
+
+	if _, ok := memoryLimit(root); ok {
+		t.Fatal("memoryLimit() ok = true for an unlimited (\"max\") limit, want false")
+	}
+}
+
+func TestMemoryLimitV1Fallback(t *testing.T) {
+	root := t.TempDir()
+	writeFixture(t, root, "memory/memory.limit_in_bytes", "67108864\n")
+
+	limit, ok := memoryLimit(root)
+	if !ok {
+		t.Fatal("memoryLimit() ok = false, want true")
+	}
+	if limit != 67108864 {
+		t.Fatalf("memoryLimit() = %d, want 67108864", limit)
+	}
+}
+
+func TestMemoryLimitV1UnlimitedSentinel(t *testing.T) {
+	root := t.TempDir()
+	// cgroup v1's "no limit" sentinel: a value near MaxInt64 rather than a
+	// missing file.
+	writeFixture(t, root, "memory/memory.limit_in_bytes", "9223372036854771712\n")
+
+	if _, ok := memoryLimit(root); ok {
+		t.Fatal("memoryLimit() ok = true for the cgroup v1 unlimited sentinel, want false")
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	tests := map[string]slog.Level{
+		"":        slog.LevelInfo,
+		"info":    slog.LevelInfo,
+		"DEBUG":   slog.LevelDebug,
+		"warn":    slog.LevelWarn,
+		"WARNING": slog.LevelWarn,
+		"error":   slog.LevelError,
+		"bogus":   slog.LevelInfo,
+	}
+
+	for in, want := range tests {
+		if got := parseLevel(in); got != want {
+			t.Errorf("parseLevel(%q) = %v, want %v", in, got, want)
+		}
+	}
+}
+
+func TestReadCgroupIntMissingFile(t *testing.T) {
+	if _, err := readCgroupInt("/does/not/exist"); err == nil {
+		t.Fatal("expected an error reading a missing cgroup file")
+	}
+}